@@ -0,0 +1,97 @@
+package jsend
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Builder incrementally constructs a JSend envelope with extension fields —
+// meta, links, pagination, and the like — before writing it with Success,
+// Fail or Error. With no WithMeta/WithLink calls, it writes the exact same
+// output as the package-level Success/Fail/Error functions.
+//
+// The reserved top-level keys "status", "data", "message" and "code" cannot
+// be overridden through WithMeta/WithLink; a collision is silently dropped
+// in favor of the envelope's own field.
+type Builder struct {
+	w     http.ResponseWriter
+	meta  map[string]interface{}
+	links map[string]interface{}
+}
+
+// New starts a Builder that writes to w.
+func New(w http.ResponseWriter) *Builder {
+	return &Builder{w: w}
+}
+
+// WithMeta attaches v under key in the envelope's top-level "meta" object.
+func (b *Builder) WithMeta(key string, v interface{}) *Builder {
+	if b.meta == nil {
+		b.meta = make(map[string]interface{})
+	}
+	b.meta[key] = v
+
+	return b
+}
+
+// WithLink attaches url under name in the envelope's top-level "links"
+// object.
+func (b *Builder) WithLink(name string, url string) *Builder {
+	if b.links == nil {
+		b.links = make(map[string]interface{})
+	}
+	b.links[name] = url
+
+	return b
+}
+
+// Success json encodes and writes data with "success" status, plus any
+// meta/links accumulated on the Builder.
+func (b *Builder) Success(data interface{}, code int) (int, error) {
+	return b.write(StatusSuccess, code, data, "")
+}
+
+// Fail json encodes and writes data with "fail" status, plus any meta/links
+// accumulated on the Builder.
+func (b *Builder) Fail(data interface{}, code int) (int, error) {
+	return b.write(StatusFail, code, data, "")
+}
+
+// Error writes msg with "error" status, plus any meta/links accumulated on
+// the Builder.
+func (b *Builder) Error(msg string, code int) (int, error) {
+	return b.write(StatusError, code, nil, msg)
+}
+
+func (b *Builder) write(status string, code int, data interface{}, msg string) (int, error) {
+	res := &jsonResponse{Status: status, Message: msg, ext: b.ext()}
+	if data != nil {
+		dataJSON, err := json.Marshal(data)
+		if err != nil {
+			return 0, ErrJSONEncode
+		}
+
+		res.Data = dataJSON
+	}
+
+	writeHeader(b.w, code)
+
+	return writeJSONResponse(b.w, res)
+}
+
+func (b *Builder) ext() map[string]json.RawMessage {
+	ext := make(map[string]json.RawMessage, 2)
+
+	if len(b.meta) > 0 {
+		if raw, err := json.Marshal(b.meta); err == nil {
+			ext["meta"] = raw
+		}
+	}
+	if len(b.links) > 0 {
+		if raw, err := json.Marshal(b.links); err == nil {
+			ext["links"] = raw
+		}
+	}
+
+	return ext
+}
@@ -0,0 +1,81 @@
+package jsend
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestBuilderSuccess(t *testing.T) {
+	rw := httptest.NewRecorder()
+	New(rw).
+		WithMeta("pagination", map[string]interface{}{"page": float64(1)}).
+		WithLink("next", "/items?page=2").
+		Success(map[string]interface{}{"id": "1"}, 200)
+
+	var body map[string]interface{}
+	json.Unmarshal(rw.Body.Bytes(), &body)
+
+	want := map[string]interface{}{
+		"status": StatusSuccess,
+		"data":   map[string]interface{}{"id": "1"},
+		"meta":   map[string]interface{}{"pagination": map[string]interface{}{"page": float64(1)}},
+		"links":  map[string]interface{}{"next": "/items?page=2"},
+	}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("Builder.Success: body: have: %#v, want: %#v", body, want)
+	}
+}
+
+func TestBuilderNoExtensionsMatchesPlainOutput(t *testing.T) {
+	rw1 := httptest.NewRecorder()
+	New(rw1).Success(testBody0, 200)
+
+	rw2 := httptest.NewRecorder()
+	Success(rw2, testBody0, 200)
+
+	if rw1.Body.String() != rw2.Body.String() {
+		t.Errorf("Builder with no extensions: have: %q, want: %q", rw1.Body.String(), rw2.Body.String())
+	}
+}
+
+func TestJSONResponseMarshalJSONReservedKeyIgnored(t *testing.T) {
+	res := &jsonResponse{
+		Status: StatusSuccess,
+		ext:    map[string]json.RawMessage{"status": json.RawMessage(`"hijacked"`)},
+	}
+
+	out, err := res.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+
+	var body map[string]interface{}
+	json.Unmarshal(out, &body)
+
+	if body["status"] != StatusSuccess {
+		t.Errorf("reserved key: have: %v, want: %v", body["status"], StatusSuccess)
+	}
+}
+
+func TestResponseSetMeta(t *testing.T) {
+	rw := httptest.NewRecorder()
+	w := Wrap(rw)
+
+	w.(MetaSetter).SetMeta("requestID", "abc-123")
+	w.WriteHeader(200)
+	w.Write([]byte(`{"id":"1"}`))
+
+	var body map[string]interface{}
+	json.Unmarshal(rw.Body.Bytes(), &body)
+
+	want := map[string]interface{}{
+		"status": StatusSuccess,
+		"data":   map[string]interface{}{"id": "1"},
+		"meta":   map[string]interface{}{"requestID": "abc-123"},
+	}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("SetMeta: body: have: %#v, want: %#v", body, want)
+	}
+}
@@ -0,0 +1,85 @@
+package jsend
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strings"
+)
+
+// defaultMinSize is the default CompressOptions.MinSize.
+const defaultMinSize = 1024
+
+// CompressOptions configures WrapCompressed.
+type CompressOptions struct {
+	// MinSize is the minimum size, in bytes, the encoded JSend envelope
+	// must reach before it is gzip-compressed. Smaller bodies, typically
+	// fail/error responses, are written as-is to avoid paying for
+	// compression on payloads too small to benefit from it. Defaults to
+	// 1024 when zero.
+	MinSize int
+}
+
+// WrapCompressed wraps rw, a ResponseWriter previously returned by
+// WrapWithRequest, so that the JSend envelope it writes is transparently
+// gzip-compressed when the request's Accept-Encoding header allows it and
+// the envelope is at least opts.MinSize bytes. opts may be nil to use the
+// defaults. If rw was not returned by Wrap or WrapWithRequest, it is wrapped
+// as if by Wrap. Unless rw came from WrapWithRequest, compression is never
+// negotiated since no request is known.
+func WrapCompressed(rw http.ResponseWriter, opts *CompressOptions) http.ResponseWriter {
+	resp, ok := rw.(*response)
+	if !ok {
+		resp = wrapResponse(rw, nil)
+	}
+
+	if opts == nil {
+		opts = &CompressOptions{}
+	}
+	if opts.MinSize <= 0 {
+		opts.MinSize = defaultMinSize
+	}
+
+	resp.compress = opts
+
+	return resp
+}
+
+func (r *response) writeCompressed(body []byte, code int) (int, error) {
+	// WriteHeader already forwarded code to the underlying ResponseWriter
+	// (a bodyless status, see bodylessStatus) before this body arrived.
+	if r.headerSent {
+		return r.rw.Write(body)
+	}
+
+	if len(body) < r.compress.MinSize || !acceptsGzip(r.request) {
+		r.rw.WriteHeader(code)
+		return r.rw.Write(body)
+	}
+
+	r.rw.Header().Set("Content-Encoding", "gzip")
+	r.rw.Header().Add("Vary", "Accept-Encoding")
+	r.rw.WriteHeader(code)
+
+	gz := gzip.NewWriter(r.rw)
+	n, err := gz.Write(body)
+	if closeErr := gz.Close(); err == nil {
+		err = closeErr
+	}
+
+	return n, err
+}
+
+func acceptsGzip(r *http.Request) bool {
+	if r == nil {
+		return false
+	}
+
+	for _, enc := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		enc = strings.TrimSpace(strings.SplitN(enc, ";", 2)[0])
+		if enc == "gzip" {
+			return true
+		}
+	}
+
+	return false
+}
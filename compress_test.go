@@ -0,0 +1,116 @@
+package jsend
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWrapCompressedLargeBody(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept-Encoding", "gzip, deflate")
+
+	rw := httptest.NewRecorder()
+	w := WrapCompressed(WrapWithRequest(rw, r), &CompressOptions{MinSize: 10})
+
+	data, _ := json.Marshal(strings.Repeat("x", 100))
+	w.WriteHeader(200)
+	w.Write(data)
+
+	if rw.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("Content-Encoding: have: %q, want: %q", rw.Header().Get("Content-Encoding"), "gzip")
+	}
+	if rw.Header().Get("Vary") != "Accept-Encoding" {
+		t.Errorf("Vary: have: %q, want: %q", rw.Header().Get("Vary"), "Accept-Encoding")
+	}
+
+	gr, err := gzip.NewReader(rw.Body)
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+	body, err := ioutil.ReadAll(gr)
+	if err != nil {
+		t.Fatalf("reading gzip body: %v", err)
+	}
+
+	var out map[string]interface{}
+	json.Unmarshal(body, &out)
+	if out["status"] != StatusSuccess {
+		t.Errorf("status: have: %v, want: %v", out["status"], StatusSuccess)
+	}
+}
+
+func TestWrapCompressedSmallBodyUncompressed(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+
+	rw := httptest.NewRecorder()
+	w := WrapCompressed(WrapWithRequest(rw, r), &CompressOptions{MinSize: 1024})
+
+	w.WriteHeader(200)
+	w.Write([]byte(`"ok"`))
+
+	if rw.Header().Get("Content-Encoding") != "" {
+		t.Errorf("Content-Encoding: have: %q, want: %q", rw.Header().Get("Content-Encoding"), "")
+	}
+
+	var out map[string]interface{}
+	json.Unmarshal(rw.Body.Bytes(), &out)
+	if out["data"] != "ok" {
+		t.Errorf("data: have: %v, want: %v", out["data"], "ok")
+	}
+}
+
+func TestWrapCompressedNoAcceptEncoding(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+
+	rw := httptest.NewRecorder()
+	w := WrapCompressed(WrapWithRequest(rw, r), &CompressOptions{MinSize: 1})
+
+	w.WriteHeader(200)
+	w.Write([]byte(`"ok"`))
+
+	if rw.Header().Get("Content-Encoding") != "" {
+		t.Errorf("Content-Encoding: have: %q, want: %q", rw.Header().Get("Content-Encoding"), "")
+	}
+}
+
+func TestWrapCompressedWriteWithoutWriteHeader(t *testing.T) {
+	rw := httptest.NewRecorder()
+	w := WrapCompressed(Wrap(rw), nil)
+
+	if _, err := w.Write([]byte(`"ok"`)); err != nil {
+		t.Fatalf("Write without WriteHeader: unexpected error: %v", err)
+	}
+
+	if rw.Code != 200 {
+		t.Errorf("code: have: %d, want: %d", rw.Code, 200)
+	}
+}
+
+func TestWrapCompressedNoContentWithoutWrite(t *testing.T) {
+	rw := httptest.NewRecorder()
+	w := WrapCompressed(Wrap(rw), nil)
+
+	w.WriteHeader(204)
+
+	if rw.Code != 204 {
+		t.Errorf("code: have: %d, want: %d", rw.Code, 204)
+	}
+}
+
+func TestWrapCompressedErrWrittenAlready(t *testing.T) {
+	rw := httptest.NewRecorder()
+	w := WrapCompressed(Wrap(rw), nil)
+
+	w.WriteHeader(200)
+	if _, err := w.Write([]byte(`"ok"`)); err != nil {
+		t.Fatalf("first write must succeed, got %v", err)
+	}
+	if _, err := w.Write([]byte(`"ok"`)); err != ErrWrittenAlready {
+		t.Errorf("second write: have: %v, want: %v", err, ErrWrittenAlready)
+	}
+}
@@ -0,0 +1,116 @@
+package jsend
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// HandlerFunc is an HTTP handler that returns its result instead of writing
+// it to the ResponseWriter directly. Handler adapts it into an http.Handler
+// that encodes the returned value as a JSend response.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) (data interface{}, code int, err error)
+
+// ErrorMapper maps an error to the HTTP status code and JSend status/data it
+// should be reported with. Errors returned from a HandlerFunc may implement
+// ErrorMapper directly, or a mapper may be registered for them with
+// RegisterErrorMapper.
+type ErrorMapper interface {
+	HTTPCode() int
+	JSendStatus() string
+	JSendData() interface{}
+}
+
+// ErrorMapperFunc attempts to map err to an ErrorMapper. It returns false if
+// it does not recognize err.
+type ErrorMapperFunc func(err error) (ErrorMapper, bool)
+
+var errorMappers []ErrorMapperFunc
+
+// RegisterErrorMapper registers fn to be consulted by Handler when an error
+// returned from a HandlerFunc does not implement ErrorMapper itself. Mappers
+// are consulted in the order they were registered; the first match wins.
+func RegisterErrorMapper(fn ErrorMapperFunc) {
+	errorMappers = append(errorMappers, fn)
+}
+
+// mappedError is a simple ErrorMapper used for built-in and default
+// mappings.
+type mappedError struct {
+	code   int
+	status string
+	data   interface{}
+}
+
+func (e *mappedError) HTTPCode() int          { return e.code }
+func (e *mappedError) JSendStatus() string    { return e.status }
+func (e *mappedError) JSendData() interface{} { return e.data }
+
+func mapError(err error) ErrorMapper {
+	if em, ok := err.(ErrorMapper); ok {
+		return em
+	}
+
+	for _, fn := range errorMappers {
+		if em, ok := fn(err); ok {
+			return em
+		}
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		// 499 Client Closed Request, as popularized by nginx.
+		return &mappedError{code: 499, status: StatusError, data: err.Error()}
+	case errors.Is(err, context.DeadlineExceeded):
+		return &mappedError{code: http.StatusGatewayTimeout, status: StatusError, data: err.Error()}
+	}
+
+	return &mappedError{code: http.StatusInternalServerError, status: StatusError, data: err.Error()}
+}
+
+// Handler adapts fn into an http.Handler. If fn returns a nil error, the
+// returned data is written with Success, Fail or Error depending on code. If
+// fn returns a non-nil error, it is resolved through mapError (consulting
+// ErrorMapper and any mapper registered with RegisterErrorMapper) and written
+// accordingly, defaulting to a 500 Error response with err.Error() as the
+// message.
+func Handler(fn HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, code, err := fn(w, r)
+		if err != nil {
+			em := mapError(err)
+			switch em.JSendStatus() {
+			case StatusFail:
+				Fail(w, em.JSendData(), em.HTTPCode())
+			case StatusSuccess:
+				Success(w, em.JSendData(), em.HTTPCode())
+			default:
+				if msg, ok := em.JSendData().(string); ok {
+					Error(w, msg, em.HTTPCode())
+				} else {
+					Error(w, err.Error(), em.HTTPCode())
+				}
+			}
+			return
+		}
+
+		switch {
+		case code >= 500:
+			Error(w, errMessage(data), code)
+		case code >= 400:
+			Fail(w, data, code)
+		default:
+			Success(w, data, code)
+		}
+	})
+}
+
+func errMessage(data interface{}) string {
+	if msg, ok := data.(string); ok {
+		return msg
+	}
+	if err, ok := data.(error); ok {
+		return err.Error()
+	}
+	return ""
+}
@@ -0,0 +1,96 @@
+package jsend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+type notFoundError struct{ msg string }
+
+func (e *notFoundError) Error() string          { return e.msg }
+func (e *notFoundError) HTTPCode() int          { return http.StatusNotFound }
+func (e *notFoundError) JSendStatus() string    { return StatusFail }
+func (e *notFoundError) JSendData() interface{} { return e.msg }
+
+func TestHandler(t *testing.T) {
+	cases := []struct {
+		label string
+		fn    HandlerFunc
+		code  int
+		body  map[string]interface{}
+	}{
+		{
+			"success",
+			func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+				return map[string]interface{}{"id": "1"}, 200, nil
+			},
+			200,
+			map[string]interface{}{"status": StatusSuccess, "data": map[string]interface{}{"id": "1"}},
+		},
+		{
+			"error-mapper",
+			func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+				return nil, 0, &notFoundError{"user not found"}
+			},
+			http.StatusNotFound,
+			map[string]interface{}{"status": StatusFail, "data": "user not found"},
+		},
+		{
+			"unmapped-error",
+			func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+				return nil, 0, errors.New("boom")
+			},
+			http.StatusInternalServerError,
+			map[string]interface{}{"status": StatusError, "message": "boom"},
+		},
+		{
+			"canceled",
+			func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+				return nil, 0, context.Canceled
+			},
+			499,
+			map[string]interface{}{"status": StatusError, "message": context.Canceled.Error()},
+		},
+	}
+
+	for _, tt := range cases {
+		rw := httptest.NewRecorder()
+		Handler(tt.fn).ServeHTTP(rw, httptest.NewRequest("GET", "/", nil))
+
+		if rw.Code != tt.code {
+			t.Errorf("%s: code: have: %d, want: %d", tt.label, rw.Code, tt.code)
+		}
+
+		var body interface{}
+		json.Unmarshal(rw.Body.Bytes(), &body)
+		if !reflect.DeepEqual(body, map[string]interface{}(tt.body)) {
+			t.Errorf("%s: body: have: %#v, want: %#v", tt.label, body, tt.body)
+		}
+	}
+}
+
+func TestRegisterErrorMapper(t *testing.T) {
+	type customErr struct{ error }
+
+	RegisterErrorMapper(func(err error) (ErrorMapper, bool) {
+		if _, ok := err.(customErr); ok {
+			return &mappedError{code: http.StatusConflict, status: StatusFail, data: "conflict"}, true
+		}
+		return nil, false
+	})
+
+	rw := httptest.NewRecorder()
+	fn := func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+		return nil, 0, customErr{errors.New("dup")}
+	}
+	Handler(fn).ServeHTTP(rw, httptest.NewRequest("GET", "/", nil))
+
+	if rw.Code != http.StatusConflict {
+		t.Errorf("RegisterErrorMapper: code: have: %d, want: %d", rw.Code, http.StatusConflict)
+	}
+}
@@ -125,6 +125,48 @@ type jsonResponse struct {
 	Status  string          `json:"status"`
 	Data    json.RawMessage `json:"data,omitempty"`
 	Message string          `json:"message,omitempty"`
+	Code    *int            `json:"code,omitempty"`
+
+	// ext holds additional top-level keys (e.g. "meta", "links") appended
+	// by Builder and response.SetMeta. It is merged into the struct fields
+	// above by MarshalJSON; reservedKeys take precedence over it.
+	ext map[string]json.RawMessage
+}
+
+// reservedKeys are the JSend envelope keys that ext can never override.
+var reservedKeys = map[string]bool{
+	"status":  true,
+	"data":    true,
+	"message": true,
+	"code":    true,
+}
+
+// MarshalJSON merges ext into the plain JSend envelope. With no ext set, it
+// marshals identically to the struct's default encoding.
+func (res *jsonResponse) MarshalJSON() ([]byte, error) {
+	type alias jsonResponse
+	base, err := json.Marshal((*alias)(res))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.ext) == 0 {
+		return base, nil
+	}
+
+	merged := make(map[string]json.RawMessage, len(res.ext)+4)
+	if err := json.Unmarshal(base, &merged); err != nil {
+		return nil, err
+	}
+
+	for k, v := range res.ext {
+		if reservedKeys[k] {
+			continue
+		}
+		merged[k] = v
+	}
+
+	return json.Marshal(merged)
 }
 
 func writeJSONResponse(w http.ResponseWriter, response *jsonResponse) (int, error) {
@@ -136,6 +178,14 @@ func writeJSONResponse(w http.ResponseWriter, response *jsonResponse) (int, erro
 	return w.Write(resJSON)
 }
 
+func writeHeader(w http.ResponseWriter, statusCode int) {
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+
+	w.WriteHeader(statusCode)
+}
+
 func write(w http.ResponseWriter, status string, statusCode int, data interface{}, error string) (int, error) {
 	res := &jsonResponse{Status: status}
 	if data != nil {
@@ -151,11 +201,7 @@ func write(w http.ResponseWriter, status string, statusCode int, data interface{
 		res.Message = error
 	}
 
-	if w.Header().Get("Content-Type") == "" {
-		w.Header().Set("Content-Type", "application/json")
-	}
-
-	w.WriteHeader(statusCode)
+	writeHeader(w, statusCode)
 
 	return writeJSONResponse(w, res)
 }
@@ -163,28 +209,80 @@ func write(w http.ResponseWriter, status string, statusCode int, data interface{
 // Wrap wraps given http.ResponseWriter and returns a response object which
 // implements http.ResponseWriter interface.
 func Wrap(rw http.ResponseWriter) http.ResponseWriter {
+	return wrapResponse(rw, nil)
+}
+
+// WrapWithRequest wraps rw like Wrap, additionally recording r so that
+// WrapCompressed can negotiate compression based on the request's
+// Accept-Encoding header.
+func WrapWithRequest(rw http.ResponseWriter, r *http.Request) http.ResponseWriter {
+	return wrapResponse(rw, r)
+}
+
+func wrapResponse(rw http.ResponseWriter, r *http.Request) *response {
 	if rw.Header().Get("Content-Type") == "" {
 		rw.Header().Set("Content-Type", "application/json")
 	}
 
-	return &response{rw: rw}
+	return &response{rw: rw, request: r}
 }
 
 // A response wraps a http.ResponseWriter.
 type response struct {
-	rw      http.ResponseWriter
-	code    int
-	written bool
+	rw         http.ResponseWriter
+	request    *http.Request
+	code       int
+	written    bool
+	headerSent bool
+	compress   *CompressOptions
+	meta       map[string]interface{}
 	sync.Mutex
 }
 
+// MetaSetter is implemented by the ResponseWriter Wrap and WrapWithRequest
+// return, letting middleware upstream of the handler attach request-scoped
+// metadata (a trace ID, a deprecation warning, ...) that is merged into the
+// "meta" field of the envelope Write produces.
+type MetaSetter interface {
+	SetMeta(key string, v interface{})
+}
+
+// SetMeta attaches v under key in the response's top-level "meta" object.
+// It has no effect once Write has already flushed the envelope.
+func (r *response) SetMeta(key string, v interface{}) {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.meta == nil {
+		r.meta = make(map[string]interface{})
+	}
+	r.meta[key] = v
+}
+
 func (r *response) Header() http.Header {
 	return r.rw.Header()
 }
 
 func (r *response) WriteHeader(code int) {
 	r.code = code
-	r.rw.WriteHeader(code)
+
+	// When compression is enabled, the underlying WriteHeader call is
+	// deferred to Write, since whether Content-Encoding is set depends on
+	// the size of the body that hasn't been seen yet. The exception is a
+	// status that HTTP forbids a body for: no Write compressible body will
+	// ever follow it, so there's nothing to gain by deferring, and doing so
+	// would silently drop the status if the caller never calls Write (e.g.
+	// WriteHeader(http.StatusNoContent) on its own).
+	if r.compress == nil || bodylessStatus(code) {
+		r.rw.WriteHeader(code)
+		r.headerSent = true
+	}
+}
+
+// bodylessStatus reports whether HTTP forbids a body for code (RFC 7230
+// §3.3.3: 1xx, 204 and 304 responses).
+func bodylessStatus(code int) bool {
+	return code == http.StatusNoContent || code == http.StatusNotModified || (code >= 100 && code < 200)
 }
 
 func (r *response) Write(data []byte) (int, error) {
@@ -207,7 +305,29 @@ func (r *response) Write(data []byte) (int, error) {
 		jr.Data = data
 	}
 
-	return writeJSONResponse(r.rw, jr)
+	if len(r.meta) > 0 {
+		if metaJSON, err := json.Marshal(r.meta); err == nil {
+			jr.ext = map[string]json.RawMessage{"meta": metaJSON}
+		}
+	}
+
+	if r.compress == nil {
+		return writeJSONResponse(r.rw, jr)
+	}
+
+	body, err := json.Marshal(jr)
+	if err != nil {
+		return 0, ErrInvalidRawJSON
+	}
+
+	// Mirror net/http's own WriteHeader(http.StatusOK) default for callers
+	// that write a body without ever calling WriteHeader explicitly.
+	code := r.code
+	if code == 0 {
+		code = http.StatusOK
+	}
+
+	return r.writeCompressed(body, code)
 }
 
 func getStatus(code int) string {
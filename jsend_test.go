@@ -127,7 +127,7 @@ func TestWriteJSONResponse(t *testing.T) {
 	rw := httptest.NewRecorder()
 	n, err := writeJSONResponse(rw, res)
 	if n != 0 || err != ErrInvalidRawJSON {
-		t.Errorf("writeJSONResponse(%q): have: (%d, %q), want: (%d, %q)", res, n, err, 0, ErrInvalidRawJSON)
+		t.Errorf("writeJSONResponse(%v): have: (%d, %q), want: (%d, %q)", res, n, err, 0, ErrInvalidRawJSON)
 	}
 }
 
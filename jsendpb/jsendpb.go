@@ -0,0 +1,25 @@
+// Package jsendpb adds protobuf support to jsend. It is kept out of the
+// core jsend package so that importing jsend does not pull in the protobuf
+// runtime for users who don't need it.
+package jsendpb
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gamegos/jsend"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// SuccessProto marshals m with protojson and writes it as a "success" JSend
+// response, letting gRPC-gateway-style services emit JSend-wrapped
+// protobufs without marshaling them by hand.
+func SuccessProto(w http.ResponseWriter, m proto.Message, code int) (int, error) {
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		return 0, err
+	}
+
+	return jsend.SuccessStream(w, json.RawMessage(data), code)
+}
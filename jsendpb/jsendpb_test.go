@@ -0,0 +1,37 @@
+package jsendpb
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestSuccessProto(t *testing.T) {
+	rw := httptest.NewRecorder()
+	m := wrapperspb.String("foo")
+
+	n, err := SuccessProto(rw, m, 200)
+	if err != nil {
+		t.Fatalf("SuccessProto: unexpected error: %v", err)
+	}
+	if n != rw.Body.Len() {
+		t.Errorf("SuccessProto: n: have: %d, want: %d", n, rw.Body.Len())
+	}
+	if rw.Code != 200 {
+		t.Errorf("SuccessProto: code: have: %d, want: %d", rw.Code, 200)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(rw.Body.Bytes(), &body); err != nil {
+		t.Fatalf("SuccessProto: invalid json body %q: %v", rw.Body.String(), err)
+	}
+
+	if body["status"] != "success" {
+		t.Errorf("SuccessProto: status: have: %v, want: %v", body["status"], "success")
+	}
+	if body["data"] != "foo" {
+		t.Errorf("SuccessProto: data: have: %v, want: %v", body["data"], "foo")
+	}
+}
@@ -0,0 +1,64 @@
+package jsend
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// countingWriter wraps an io.Writer to track the number of bytes written
+// across multiple Write calls, so streaming helpers can report a byte count
+// like the rest of the package's write functions do.
+type countingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += n
+	return n, err
+}
+
+// writeStream streams a JSend envelope to w without buffering the whole
+// response in memory: only the "status" and "data" keys are written
+// directly, and v is encoded straight onto the wire with json.Encoder
+// instead of first being marshaled into a json.RawMessage and remarshaled
+// as part of the envelope.
+func writeStream(w http.ResponseWriter, status string, statusCode int, v interface{}) (int, error) {
+	writeHeader(w, statusCode)
+
+	cw := &countingWriter{w: w}
+
+	if _, err := io.WriteString(cw, `{"status":"`+status+`"`); err != nil {
+		return cw.n, err
+	}
+
+	if v != nil {
+		if _, err := io.WriteString(cw, `,"data":`); err != nil {
+			return cw.n, err
+		}
+		if err := json.NewEncoder(cw).Encode(v); err != nil {
+			return cw.n, err
+		}
+	}
+
+	if _, err := io.WriteString(cw, `}`); err != nil {
+		return cw.n, err
+	}
+
+	return cw.n, nil
+}
+
+// SuccessStream encodes v directly onto w as a "success" JSend response
+// using json.Encoder, skipping the intermediate buffering Success performs.
+// Prefer it for large payloads.
+func SuccessStream(w http.ResponseWriter, v interface{}, code int) (int, error) {
+	return writeStream(w, StatusSuccess, code, v)
+}
+
+// FailStream encodes v directly onto w as a "fail" JSend response using
+// json.Encoder. See SuccessStream.
+func FailStream(w http.ResponseWriter, v interface{}, code int) (int, error) {
+	return writeStream(w, StatusFail, code, v)
+}
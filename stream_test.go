@@ -0,0 +1,51 @@
+package jsend
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestSuccessStream(t *testing.T) {
+	rw := httptest.NewRecorder()
+	n, err := SuccessStream(rw, map[string]interface{}{"id": "1"}, 200)
+	if err != nil {
+		t.Fatalf("SuccessStream: unexpected error: %v", err)
+	}
+	if n != rw.Body.Len() {
+		t.Errorf("SuccessStream: n: have: %d, want: %d", n, rw.Body.Len())
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(rw.Body.Bytes(), &body); err != nil {
+		t.Fatalf("SuccessStream: invalid json body %q: %v", rw.Body.String(), err)
+	}
+
+	want := map[string]interface{}{
+		"status": StatusSuccess,
+		"data":   map[string]interface{}{"id": "1"},
+	}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("SuccessStream: body: have: %#v, want: %#v", body, want)
+	}
+}
+
+func TestFailStream(t *testing.T) {
+	rw := httptest.NewRecorder()
+	FailStream(rw, map[string]interface{}{"field": "invalid"}, 400)
+
+	var body map[string]interface{}
+	json.Unmarshal(rw.Body.Bytes(), &body)
+
+	want := map[string]interface{}{
+		"status": StatusFail,
+		"data":   map[string]interface{}{"field": "invalid"},
+	}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("FailStream: body: have: %#v, want: %#v", body, want)
+	}
+	if rw.Code != 400 {
+		t.Errorf("FailStream: code: have: %d, want: %d", rw.Code, 400)
+	}
+}
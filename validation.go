@@ -0,0 +1,72 @@
+package jsend
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+)
+
+// ErrorWithCode writes an "error" response carrying the optional JSend
+// application-level code and an optional data payload alongside the
+// message, per the JSend spec's "message, code and data are all optional"
+// clause for error responses.
+func ErrorWithCode(w http.ResponseWriter, msg string, appCode int, httpCode int, data interface{}) (int, error) {
+	res := &jsonResponse{Status: StatusError, Message: msg, Code: &appCode}
+
+	if data != nil {
+		dataJSON, err := json.Marshal(data)
+		if err != nil {
+			return 0, ErrJSONEncode
+		}
+
+		res.Data = dataJSON
+	}
+
+	writeHeader(w, httpCode)
+
+	return writeJSONResponse(w, res)
+}
+
+// FailValidation writes a "fail" response whose data is a map of field name
+// to validation error message, matching the shape APIs commonly use to
+// report per-field validation failures.
+func FailValidation(w http.ResponseWriter, fields map[string]string, code int) (int, error) {
+	return Fail(w, fields, code)
+}
+
+// FieldError is satisfied by the per-field errors produced by struct
+// validator packages, such as gopkg.in/go-playground/validator.v9's
+// FieldError. It is declared locally so FailValidatorErrors can integrate
+// with those packages without importing them.
+type FieldError interface {
+	error
+	Field() string
+}
+
+// FailValidatorErrors converts a validator error into a FailValidation
+// response. err is expected to be a slice of values implementing FieldError,
+// as returned by validator.v9's Validate.Struct (its ValidationErrors type).
+// Elements that do not implement FieldError are ignored; if none do, err is
+// reported as a single "error" field instead.
+func FailValidatorErrors(w http.ResponseWriter, err error, code int) (int, error) {
+	fields := make(map[string]string)
+
+	if err == nil {
+		return FailValidation(w, fields, code)
+	}
+
+	v := reflect.ValueOf(err)
+	if v.Kind() == reflect.Slice {
+		for i := 0; i < v.Len(); i++ {
+			if fe, ok := v.Index(i).Interface().(FieldError); ok {
+				fields[fe.Field()] = fe.Error()
+			}
+		}
+	}
+
+	if len(fields) == 0 {
+		fields["error"] = err.Error()
+	}
+
+	return FailValidation(w, fields, code)
+}
@@ -0,0 +1,96 @@
+package jsend
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestErrorWithCode(t *testing.T) {
+	rw := httptest.NewRecorder()
+	ErrorWithCode(rw, "invalid request", 1042, 400, map[string]string{"field": "name"})
+
+	var body map[string]interface{}
+	json.Unmarshal(rw.Body.Bytes(), &body)
+
+	want := map[string]interface{}{
+		"status":  StatusError,
+		"message": "invalid request",
+		"code":    float64(1042),
+		"data":    map[string]interface{}{"field": "name"},
+	}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("ErrorWithCode: body: have: %#v, want: %#v", body, want)
+	}
+}
+
+func TestFailValidation(t *testing.T) {
+	rw := httptest.NewRecorder()
+	FailValidation(rw, map[string]string{"email": "is required"}, 422)
+
+	var body map[string]interface{}
+	json.Unmarshal(rw.Body.Bytes(), &body)
+
+	want := map[string]interface{}{
+		"status": StatusFail,
+		"data":   map[string]interface{}{"email": "is required"},
+	}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("FailValidation: body: have: %#v, want: %#v", body, want)
+	}
+	if rw.Code != 422 {
+		t.Errorf("FailValidation: code: have: %d, want: %d", rw.Code, 422)
+	}
+}
+
+type testFieldError struct {
+	field, reason string
+}
+
+func (e *testFieldError) Error() string { return e.reason }
+func (e *testFieldError) Field() string { return e.field }
+
+type testValidationErrors []*testFieldError
+
+func (e testValidationErrors) Error() string { return "validation failed" }
+
+func TestFailValidatorErrors(t *testing.T) {
+	errs := testValidationErrors{
+		{"Email", "required"},
+		{"Age", "min"},
+	}
+
+	rw := httptest.NewRecorder()
+	FailValidatorErrors(rw, errs, 422)
+
+	var body map[string]interface{}
+	json.Unmarshal(rw.Body.Bytes(), &body)
+
+	want := map[string]interface{}{
+		"status": StatusFail,
+		"data": map[string]interface{}{
+			"Email": "required",
+			"Age":   "min",
+		},
+	}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("FailValidatorErrors: body: have: %#v, want: %#v", body, want)
+	}
+}
+
+func TestFailValidatorErrorsNilErr(t *testing.T) {
+	rw := httptest.NewRecorder()
+	FailValidatorErrors(rw, nil, 422)
+
+	var body map[string]interface{}
+	json.Unmarshal(rw.Body.Bytes(), &body)
+
+	want := map[string]interface{}{
+		"status": StatusFail,
+		"data":   map[string]interface{}{},
+	}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("FailValidatorErrors(nil): body: have: %#v, want: %#v", body, want)
+	}
+}